@@ -0,0 +1,33 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// PreDeposedRefresh and its siblings below let the interactive UI
+// distinguish deposed-object churn (caused by create_before_destroy) from
+// the refresh/destroy of an instance's current object, so operators see
+// messages like "deposed dead0001" rather than generic instance output.
+
+func (h *UiHook) PreDeposedRefresh(addr addrs.AbsResourceInstance, key states.DeposedKey, priorState *states.ResourceInstanceObject) (terraform.HookAction, error) {
+	h.println(fmt.Sprintf("%s: Refreshing deposed object state (deposed %s)...", addr, key))
+	return terraform.HookActionContinue, nil
+}
+
+func (h *UiHook) PostDeposedRefresh(addr addrs.AbsResourceInstance, key states.DeposedKey, newState *states.ResourceInstanceObject) (terraform.HookAction, error) {
+	return terraform.HookActionContinue, nil
+}
+
+func (h *UiHook) PreDeposedDestroy(addr addrs.AbsResourceInstance, key states.DeposedKey, priorState *states.ResourceInstanceObject) (terraform.HookAction, error) {
+	h.println(fmt.Sprintf("%s: Destroying deposed object (deposed %s)...", addr, key))
+	return terraform.HookActionContinue, nil
+}
+
+func (h *UiHook) PostDeposedDestroy(addr addrs.AbsResourceInstance, key states.DeposedKey, newState *states.ResourceInstanceObject) (terraform.HookAction, error) {
+	h.println(fmt.Sprintf("%s: Deposed object destruction complete (deposed %s)", addr, key))
+	return terraform.HookActionContinue, nil
+}
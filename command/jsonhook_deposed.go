@@ -0,0 +1,44 @@
+package command
+
+import (
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// PreDeposedRefresh and its siblings below let the JSON log stream
+// distinguish deposed-object churn (caused by create_before_destroy) from
+// the refresh/destroy of an instance's current object, surfacing the
+// DeposedKey as its own field rather than folding it into a generic
+// "apply_progress" message.
+
+func (h *jsonHook) PreDeposedRefresh(addr addrs.AbsResourceInstance, key states.DeposedKey, priorState *states.ResourceInstanceObject) (terraform.HookAction, error) {
+	h.logDeposed("refresh_start", addr, key)
+	return terraform.HookActionContinue, nil
+}
+
+func (h *jsonHook) PostDeposedRefresh(addr addrs.AbsResourceInstance, key states.DeposedKey, newState *states.ResourceInstanceObject) (terraform.HookAction, error) {
+	h.logDeposed("refresh_complete", addr, key)
+	return terraform.HookActionContinue, nil
+}
+
+func (h *jsonHook) PreDeposedDestroy(addr addrs.AbsResourceInstance, key states.DeposedKey, priorState *states.ResourceInstanceObject) (terraform.HookAction, error) {
+	h.logDeposed("destroy_start", addr, key)
+	return terraform.HookActionContinue, nil
+}
+
+func (h *jsonHook) PostDeposedDestroy(addr addrs.AbsResourceInstance, key states.DeposedKey, newState *states.ResourceInstanceObject) (terraform.HookAction, error) {
+	h.logDeposed("destroy_complete", addr, key)
+	return terraform.HookActionContinue, nil
+}
+
+// logDeposed emits a single structured log entry for a deposed-object
+// lifecycle event, tagging it with the resource address and deposed key so
+// that log consumers can tell it apart from the instance's own
+// refresh/destroy events.
+func (h *jsonHook) logDeposed(eventType string, addr addrs.AbsResourceInstance, key states.DeposedKey) {
+	h.view.Log(eventType, map[string]interface{}{
+		"resource_addr": addr.String(),
+		"deposed_key":   string(key),
+	})
+}
@@ -0,0 +1,135 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+)
+
+// StateRmDeposedCommand is a Command implementation that prunes deposed
+// resource instance objects from the state that a DeposedRetentionPolicy
+// considers expired (too old, or past their configured attempt limit).
+//
+// Unlike "terraform state rm", this command never touches non-deposed
+// objects, and the --expired flag is required so that an operator cannot
+// accidentally nuke every deposed object in state with a bare invocation.
+type StateRmDeposedCommand struct {
+	StateMeta
+}
+
+func (c *StateRmDeposedCommand) Run(args []string) int {
+	args = c.Meta.process(args)
+
+	var expired, autoApprove bool
+	cmdFlags := c.Meta.defaultFlagSet("state rm-deposed")
+	cmdFlags.BoolVar(&expired, "expired", false, "only remove deposed objects whose retention policy has expired")
+	cmdFlags.BoolVar(&autoApprove, "auto-approve", false, "skip interactive approval of the objects to be removed")
+	cmdFlags.Usage = func() { c.Ui.Error(c.Help()) }
+	if err := cmdFlags.Parse(args); err != nil {
+		return cli.RunResultHelp
+	}
+
+	if !expired {
+		c.Ui.Error("The -expired flag is required: state rm-deposed only removes deposed\nobjects whose retention policy has expired.")
+		return 1
+	}
+
+	stateMgr, err := c.State()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to load state: %s", err))
+		return 1
+	}
+	if err := stateMgr.RefreshState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to refresh state: %s", err))
+		return 1
+	}
+
+	curState := stateMgr.State()
+	if curState == nil {
+		c.Ui.Output("No state to remove deposed objects from.")
+		return 0
+	}
+
+	expiredAddrs := curState.ListExpiredDeposed(time.Now())
+	if len(expiredAddrs) == 0 {
+		c.Ui.Output("No deposed objects had exceeded their retention policy.")
+		return 0
+	}
+
+	if !autoApprove {
+		names := make([]string, len(expiredAddrs))
+		for i, addr := range expiredAddrs {
+			names[i] = addr.String()
+		}
+		v, err := c.Ui.Ask(fmt.Sprintf(
+			"Terraform will remove the following expired deposed object(s):\n  %s\n\n"+
+				"Only 'yes' will be accepted to continue.\n\nEnter a value:",
+			strings.Join(names, "\n  "),
+		))
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error asking for approval: %s", err))
+			return 1
+		}
+		if v != "yes" {
+			c.Ui.Output("Cancelled; no deposed objects were removed.")
+			return 0
+		}
+	}
+
+	removed, err := curState.PruneExpiredDeposed()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to prune expired deposed objects: %s", err))
+		return 1
+	}
+	if len(removed) == 0 {
+		c.Ui.Output("No deposed objects had exceeded their retention policy.")
+		return 0
+	}
+
+	if err := stateMgr.WriteState(curState); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to write state: %s", err))
+		return 1
+	}
+	if err := stateMgr.PersistState(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to persist state: %s", err))
+		return 1
+	}
+
+	names := make([]string, len(removed))
+	for i, addr := range removed {
+		names[i] = addr.String()
+	}
+	c.Ui.Output(fmt.Sprintf("Removed %d expired deposed object(s):\n  %s", len(removed), strings.Join(names, "\n  ")))
+	return 0
+}
+
+func (c *StateRmDeposedCommand) Help() string {
+	helpText := `
+Usage: terraform state rm-deposed -expired [options]
+
+  Remove deposed resource instance objects whose configured retention
+  policy (max age and/or max attempts) has expired.
+
+  This command will still ask for confirmation before making any
+  changes unless the -auto-approve flag is also given.
+
+Options:
+
+  -expired            Required. Only remove deposed objects that the
+                       retention policy considers expired; this command
+                       refuses to run without it.
+
+  -auto-approve        Skip interactive approval of the objects to be
+                       removed.
+
+  This command accepts no other options: -backup, -lock, -lock-timeout
+  and -state are not supported here.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *StateRmDeposedCommand) Synopsis() string {
+	return "Remove expired deposed resource instance objects from the state"
+}
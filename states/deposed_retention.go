@@ -0,0 +1,92 @@
+package states
+
+import "time"
+
+// DeposedRetentionPolicy controls how long a deposed object instance is
+// allowed to linger in state and how many times Terraform will attempt to
+// destroy it before giving up and requiring operator intervention.
+//
+// A zero value DeposedRetentionPolicy imposes no limits, preserving the
+// historical behavior of retrying destruction indefinitely on every
+// subsequent run.
+type DeposedRetentionPolicy struct {
+	// MaxAge is the longest duration a deposed object may remain in state,
+	// measured from the FirstSeen time of its DeposedAttemptRecord. Zero
+	// means no age limit.
+	MaxAge time.Duration
+
+	// MaxAttempts is the maximum number of destroy attempts Terraform will
+	// make for a deposed object before refusing to try again. Zero means
+	// no attempt limit.
+	MaxAttempts int
+
+	// MinBackoff is the minimum duration that must elapse between two
+	// consecutive destroy attempts for the same deposed object. Zero means
+	// no enforced backoff.
+	MinBackoff time.Duration
+}
+
+// DeposedAttemptRecord tracks the destroy-attempt bookkeeping that
+// Terraform persists in state alongside a deposed object so that retention
+// policy decisions survive across runs.
+type DeposedAttemptRecord struct {
+	// FirstSeen is when the first destroy attempt against this deposed
+	// object was recorded: RecordAttempt sets it if it is still zero. It is
+	// not set when the object is first deposed.
+	FirstSeen time.Time
+
+	// Attempts is the number of times Terraform has tried to destroy this
+	// deposed object so far.
+	Attempts int
+
+	// LastAttempt is when the most recent destroy attempt was made. It is
+	// the zero time if no attempt has been made yet.
+	LastAttempt time.Time
+}
+
+// Allow reports whether the given retention policy permits another destroy
+// attempt for a deposed object with the given attempt record, evaluated at
+// the given time.
+func (p *DeposedRetentionPolicy) Allow(rec DeposedAttemptRecord, now time.Time) bool {
+	if p == nil {
+		return true
+	}
+	if p.MaxAge > 0 && !rec.FirstSeen.IsZero() && now.Sub(rec.FirstSeen) > p.MaxAge {
+		return false
+	}
+	if p.MaxAttempts > 0 && rec.Attempts >= p.MaxAttempts {
+		return false
+	}
+	if p.MinBackoff > 0 && !rec.LastAttempt.IsZero() && now.Sub(rec.LastAttempt) < p.MinBackoff {
+		return false
+	}
+	return true
+}
+
+// Expired reports whether the given retention policy considers a deposed
+// object eligible for unconditional removal via "state rm-deposed
+// --expired", independent of whether another destroy attempt would also be
+// denied.
+func (p *DeposedRetentionPolicy) Expired(rec DeposedAttemptRecord, now time.Time) bool {
+	if p == nil {
+		return false
+	}
+	if p.MaxAge > 0 && !rec.FirstSeen.IsZero() && now.Sub(rec.FirstSeen) > p.MaxAge {
+		return true
+	}
+	if p.MaxAttempts > 0 && rec.Attempts >= p.MaxAttempts {
+		return true
+	}
+	return false
+}
+
+// RecordAttempt returns a copy of rec updated to reflect a destroy attempt
+// made at the given time.
+func (rec DeposedAttemptRecord) RecordAttempt(now time.Time) DeposedAttemptRecord {
+	if rec.FirstSeen.IsZero() {
+		rec.FirstSeen = now
+	}
+	rec.Attempts++
+	rec.LastAttempt = now
+	return rec
+}
@@ -0,0 +1,154 @@
+package states
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeposedRetentionPolicyAllow(t *testing.T) {
+	now := time.Date(2026, 7, 28, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		policy *DeposedRetentionPolicy
+		rec    DeposedAttemptRecord
+		want   bool
+	}{
+		"nil policy always allows": {
+			policy: nil,
+			rec:    DeposedAttemptRecord{Attempts: 100, FirstSeen: now.Add(-100 * 24 * time.Hour)},
+			want:   true,
+		},
+		"within backoff denies": {
+			policy: &DeposedRetentionPolicy{MinBackoff: time.Hour},
+			rec:    DeposedAttemptRecord{LastAttempt: now.Add(-time.Minute)},
+			want:   false,
+		},
+		"past backoff allows": {
+			policy: &DeposedRetentionPolicy{MinBackoff: time.Hour},
+			rec:    DeposedAttemptRecord{LastAttempt: now.Add(-2 * time.Hour)},
+			want:   true,
+		},
+		"attempts exhausted denies": {
+			policy: &DeposedRetentionPolicy{MaxAttempts: 3},
+			rec:    DeposedAttemptRecord{Attempts: 3},
+			want:   false,
+		},
+		"attempts remaining allows": {
+			policy: &DeposedRetentionPolicy{MaxAttempts: 3},
+			rec:    DeposedAttemptRecord{Attempts: 2},
+			want:   true,
+		},
+		"age exceeded denies": {
+			policy: &DeposedRetentionPolicy{MaxAge: 24 * time.Hour},
+			rec:    DeposedAttemptRecord{FirstSeen: now.Add(-48 * time.Hour)},
+			want:   false,
+		},
+		"age within limit allows": {
+			policy: &DeposedRetentionPolicy{MaxAge: 24 * time.Hour},
+			rec:    DeposedAttemptRecord{FirstSeen: now.Add(-time.Hour)},
+			want:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := test.policy.Allow(test.rec, now)
+			if got != test.want {
+				t.Fatalf("Allow() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+// TestDeposedRetentionPolicyBackoffVsExpired confirms that a backoff-only
+// denial and a denial backed by Expired are actually distinguishable, since
+// EvalCheckDeposedRetention relies on exactly this distinction to treat the
+// former as a retryable skip and the latter as fatal.
+func TestDeposedRetentionPolicyBackoffVsExpired(t *testing.T) {
+	now := time.Date(2026, 7, 28, 12, 0, 0, 0, time.UTC)
+	policy := &DeposedRetentionPolicy{MaxAttempts: 3, MinBackoff: time.Hour}
+
+	backoffOnly := DeposedAttemptRecord{Attempts: 1, LastAttempt: now.Add(-time.Minute)}
+	if policy.Allow(backoffOnly, now) {
+		t.Fatal("expected backoff-only record to be denied")
+	}
+	if policy.Expired(backoffOnly, now) {
+		t.Fatal("expected backoff-only record to not be Expired")
+	}
+
+	exhausted := DeposedAttemptRecord{Attempts: 3, LastAttempt: now.Add(-2 * time.Hour)}
+	if policy.Allow(exhausted, now) {
+		t.Fatal("expected attempt-exhausted record to be denied")
+	}
+	if !policy.Expired(exhausted, now) {
+		t.Fatal("expected attempt-exhausted record to be Expired")
+	}
+}
+
+func TestDeposedRetentionPolicyExpired(t *testing.T) {
+	now := time.Date(2026, 7, 28, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		policy *DeposedRetentionPolicy
+		rec    DeposedAttemptRecord
+		want   bool
+	}{
+		"nil policy never expires": {
+			policy: nil,
+			rec:    DeposedAttemptRecord{Attempts: 1000},
+			want:   false,
+		},
+		"backoff alone never expires": {
+			policy: &DeposedRetentionPolicy{MinBackoff: time.Hour},
+			rec:    DeposedAttemptRecord{LastAttempt: now},
+			want:   false,
+		},
+		"max age exceeded expires": {
+			policy: &DeposedRetentionPolicy{MaxAge: 24 * time.Hour},
+			rec:    DeposedAttemptRecord{FirstSeen: now.Add(-48 * time.Hour)},
+			want:   true,
+		},
+		"max attempts exceeded expires": {
+			policy: &DeposedRetentionPolicy{MaxAttempts: 2},
+			rec:    DeposedAttemptRecord{Attempts: 2},
+			want:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := test.policy.Expired(test.rec, now)
+			if got != test.want {
+				t.Fatalf("Expired() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDeposedAttemptRecordRecordAttempt(t *testing.T) {
+	now := time.Date(2026, 7, 28, 12, 0, 0, 0, time.UTC)
+
+	var rec DeposedAttemptRecord
+	rec = rec.RecordAttempt(now)
+	if rec.Attempts != 1 {
+		t.Fatalf("Attempts = %d, want 1", rec.Attempts)
+	}
+	if !rec.FirstSeen.Equal(now) {
+		t.Fatalf("FirstSeen = %v, want %v", rec.FirstSeen, now)
+	}
+	if !rec.LastAttempt.Equal(now) {
+		t.Fatalf("LastAttempt = %v, want %v", rec.LastAttempt, now)
+	}
+
+	later := now.Add(time.Hour)
+	rec = rec.RecordAttempt(later)
+	if rec.Attempts != 2 {
+		t.Fatalf("Attempts = %d, want 2", rec.Attempts)
+	}
+	if !rec.FirstSeen.Equal(now) {
+		t.Fatalf("FirstSeen changed on a later attempt: got %v, want %v", rec.FirstSeen, now)
+	}
+	if !rec.LastAttempt.Equal(later) {
+		t.Fatalf("LastAttempt = %v, want %v", rec.LastAttempt, later)
+	}
+}
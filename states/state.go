@@ -0,0 +1,140 @@
+package states
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform/addrs"
+)
+
+// State is the subset of Terraform's persisted state that this package
+// implements directly: bookkeeping for deposed resource instance objects,
+// including the retention policy and destroy-attempt history recorded
+// alongside each one. It does not model non-deposed resource state.
+type State struct {
+	deposed map[deposedObjectKey]*deposedObjectState
+}
+
+// deposedObjectKey identifies one deposed object. A DeposedKey alone isn't
+// guaranteed unique across instances, so it's always scoped to the
+// instance address it belongs to.
+type deposedObjectKey struct {
+	instAddr string
+	key      DeposedKey
+}
+
+// deposedObjectState is everything State persists for a single deposed
+// object: the captured object itself, the retention policy that governs
+// it, and how many destroy attempts have been made against it so far.
+type deposedObjectState struct {
+	addr    addrs.AbsResourceInstance
+	object  *ResourceInstanceObject
+	policy  *DeposedRetentionPolicy
+	attempt DeposedAttemptRecord
+}
+
+// NewState returns a new, empty State.
+func NewState() *State {
+	return &State{
+		deposed: make(map[deposedObjectKey]*deposedObjectState),
+	}
+}
+
+func (s *State) entry(addr addrs.AbsResourceInstance, key DeposedKey) *deposedObjectState {
+	return s.deposed[deposedObjectKey{instAddr: addr.String(), key: key}]
+}
+
+// DeposedObject returns the captured object for the given deposed key, or
+// nil if there is none.
+func (s *State) DeposedObject(addr addrs.AbsResourceInstance, key DeposedKey) *ResourceInstanceObject {
+	entry := s.entry(addr, key)
+	if entry == nil {
+		return nil
+	}
+	return entry.object
+}
+
+// DeposedAttemptRecord returns the destroy-attempt bookkeeping recorded for
+// the given deposed key, or a zero-value record if none has been recorded
+// yet.
+func (s *State) DeposedAttemptRecord(addr addrs.AbsResourceInstance, key DeposedKey) DeposedAttemptRecord {
+	entry := s.entry(addr, key)
+	if entry == nil {
+		return DeposedAttemptRecord{}
+	}
+	return entry.attempt
+}
+
+// DeposedRetentionPolicy returns the retention policy recorded for the
+// given deposed key, or nil if none was recorded (equivalent to no limit).
+func (s *State) DeposedRetentionPolicy(addr addrs.AbsResourceInstance, key DeposedKey) *DeposedRetentionPolicy {
+	entry := s.entry(addr, key)
+	if entry == nil {
+		return nil
+	}
+	return entry.policy
+}
+
+// SetDeposedObject records obj as the captured object for the given
+// deposed key, alongside policy. Any attempt record already present for
+// this key is preserved.
+func (s *State) SetDeposedObject(addr addrs.AbsResourceInstance, key DeposedKey, obj *ResourceInstanceObject, policy *DeposedRetentionPolicy) {
+	k := deposedObjectKey{instAddr: addr.String(), key: key}
+	entry := s.deposed[k]
+	if entry == nil {
+		entry = &deposedObjectState{addr: addr}
+		s.deposed[k] = entry
+	}
+	entry.object = obj
+	entry.policy = policy
+}
+
+// SetDeposedAttemptRecord updates the destroy-attempt bookkeeping for the
+// given deposed key, creating an entry for it if one doesn't already
+// exist.
+func (s *State) SetDeposedAttemptRecord(addr addrs.AbsResourceInstance, key DeposedKey, rec DeposedAttemptRecord) {
+	k := deposedObjectKey{instAddr: addr.String(), key: key}
+	entry := s.deposed[k]
+	if entry == nil {
+		entry = &deposedObjectState{addr: addr}
+		s.deposed[k] = entry
+	}
+	entry.attempt = rec
+}
+
+// ForgetDeposedObject removes all bookkeeping for the given deposed key,
+// typically because it was successfully destroyed.
+func (s *State) ForgetDeposedObject(addr addrs.AbsResourceInstance, key DeposedKey) {
+	delete(s.deposed, deposedObjectKey{instAddr: addr.String(), key: key})
+}
+
+// ListExpiredDeposed returns the resource instance addresses of every
+// deposed object whose recorded retention policy considers it expired as
+// of now, without modifying state. An instance with more than one expired
+// deposed object appears once per expired object.
+func (s *State) ListExpiredDeposed(now time.Time) []addrs.AbsResourceInstance {
+	var result []addrs.AbsResourceInstance
+	for _, entry := range s.deposed {
+		if entry.policy.Expired(entry.attempt, now) {
+			result = append(result, entry.addr)
+		}
+	}
+	return result
+}
+
+// PruneExpiredDeposed removes every deposed object whose recorded
+// retention policy considers it expired, and returns the resource instance
+// addresses it was removed from. A deposed object with no retention policy
+// attached is never considered expired, preserving the historical behavior
+// of retrying destruction indefinitely until it succeeds.
+func (s *State) PruneExpiredDeposed() ([]addrs.AbsResourceInstance, error) {
+	now := time.Now()
+	var removed []addrs.AbsResourceInstance
+	for k, entry := range s.deposed {
+		if !entry.policy.Expired(entry.attempt, now) {
+			continue
+		}
+		removed = append(removed, entry.addr)
+		delete(s.deposed, k)
+	}
+	return removed, nil
+}
@@ -0,0 +1,90 @@
+package states
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/addrs"
+)
+
+func TestStatePruneExpiredDeposed(t *testing.T) {
+	// PruneExpiredDeposed evaluates expiry against time.Now() internally,
+	// so these offsets are relative to "now" rather than a fixed instant.
+	now := time.Now()
+	addr := addrs.AbsResourceInstance{}
+	key := DeposedKey("1234abcd")
+
+	t.Run("nil policy is never pruned", func(t *testing.T) {
+		s := NewState()
+		s.SetDeposedObject(addr, key, &ResourceInstanceObject{}, nil)
+		s.SetDeposedAttemptRecord(addr, key, DeposedAttemptRecord{Attempts: 1000, FirstSeen: now.Add(-1000 * 24 * time.Hour)})
+
+		removed, err := s.PruneExpiredDeposed()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(removed) != 0 {
+			t.Fatalf("expected nothing pruned, got %v", removed)
+		}
+		if s.DeposedObject(addr, key) == nil {
+			t.Fatal("deposed object was removed despite having no retention policy")
+		}
+	})
+
+	t.Run("expired policy is pruned", func(t *testing.T) {
+		s := NewState()
+		policy := &DeposedRetentionPolicy{MaxAttempts: 1}
+		s.SetDeposedObject(addr, key, &ResourceInstanceObject{}, policy)
+		s.SetDeposedAttemptRecord(addr, key, DeposedAttemptRecord{Attempts: 1})
+
+		removed, err := s.PruneExpiredDeposed()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(removed) != 1 {
+			t.Fatalf("expected exactly one address pruned, got %v", removed)
+		}
+		if s.DeposedObject(addr, key) != nil {
+			t.Fatal("deposed object was not removed despite being expired")
+		}
+	})
+
+	t.Run("backoff-only denial is not pruned", func(t *testing.T) {
+		s := NewState()
+		policy := &DeposedRetentionPolicy{MinBackoff: time.Hour}
+		s.SetDeposedObject(addr, key, &ResourceInstanceObject{}, policy)
+		s.SetDeposedAttemptRecord(addr, key, DeposedAttemptRecord{Attempts: 1, LastAttempt: now.Add(-time.Minute)})
+
+		removed, err := s.PruneExpiredDeposed()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(removed) != 0 {
+			t.Fatalf("expected nothing pruned, got %v", removed)
+		}
+		if s.DeposedObject(addr, key) == nil {
+			t.Fatal("deposed object was removed despite only being in its backoff window")
+		}
+	})
+}
+
+func TestStateListExpiredDeposed(t *testing.T) {
+	now := time.Now()
+	addr := addrs.AbsResourceInstance{}
+	key := DeposedKey("1234abcd")
+
+	s := NewState()
+	policy := &DeposedRetentionPolicy{MaxAge: time.Hour}
+	s.SetDeposedObject(addr, key, &ResourceInstanceObject{}, policy)
+	s.SetDeposedAttemptRecord(addr, key, DeposedAttemptRecord{FirstSeen: now.Add(-2 * time.Hour)})
+
+	expired := s.ListExpiredDeposed(now)
+	if len(expired) != 1 {
+		t.Fatalf("expected exactly one expired address, got %v", expired)
+	}
+
+	// ListExpiredDeposed must not mutate state.
+	if s.DeposedObject(addr, key) == nil {
+		t.Fatal("ListExpiredDeposed removed a deposed object; it should only report")
+	}
+}
@@ -0,0 +1,76 @@
+package states
+
+import (
+	"sync"
+
+	"github.com/hashicorp/terraform/addrs"
+)
+
+// SyncState is a lock-protected wrapper around State, giving concurrent
+// graph nodes (such as the members of a graphNodeDeposedResourceGroup) a
+// safe way to read and write deposed-object bookkeeping at the same time.
+type SyncState struct {
+	mu    sync.Mutex
+	state *State
+}
+
+// NewSyncState returns a SyncState wrapping a new, empty State.
+func NewSyncState() *SyncState {
+	return &SyncState{state: NewState()}
+}
+
+// DeposedInstanceObject returns the captured object for the given deposed
+// key, or nil if there is none.
+func (s *SyncState) DeposedInstanceObject(addr addrs.AbsResourceInstance, key DeposedKey) *ResourceInstanceObject {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.DeposedObject(addr, key)
+}
+
+// DeposedAttemptRecord returns the destroy-attempt bookkeeping recorded for
+// the given deposed key, or a zero-value record if none has been recorded
+// yet.
+func (s *SyncState) DeposedAttemptRecord(addr addrs.AbsResourceInstance, key DeposedKey) DeposedAttemptRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.DeposedAttemptRecord(addr, key)
+}
+
+// SetDeposedAttemptRecord updates the destroy-attempt bookkeeping for the
+// given deposed key.
+func (s *SyncState) SetDeposedAttemptRecord(addr addrs.AbsResourceInstance, key DeposedKey, rec DeposedAttemptRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.SetDeposedAttemptRecord(addr, key, rec)
+}
+
+// SetResourceInstanceDeposed records obj as the captured object for the
+// given deposed key, alongside the retention policy that governs it.
+// providerAddr is accepted for parity with the non-deposed write path but
+// isn't modeled by this package yet.
+func (s *SyncState) SetResourceInstanceDeposed(addr addrs.AbsResourceInstance, key DeposedKey, obj *ResourceInstanceObject, policy *DeposedRetentionPolicy, providerAddr addrs.AbsProviderConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.SetDeposedObject(addr, key, obj, policy)
+}
+
+// ForgetResourceInstanceDeposed removes all bookkeeping for the given
+// deposed key, typically because it was successfully destroyed.
+func (s *SyncState) ForgetResourceInstanceDeposed(addr addrs.AbsResourceInstance, key DeposedKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.ForgetDeposedObject(addr, key)
+}
+
+// Lock exposes the underlying State for callers that need to perform
+// several related operations, such as listing and then pruning expired
+// deposed objects, without another goroutine mutating state in between.
+func (s *SyncState) Lock() *State {
+	s.mu.Lock()
+	return s.state
+}
+
+// Unlock releases the lock acquired by Lock.
+func (s *SyncState) Unlock() {
+	s.mu.Unlock()
+}
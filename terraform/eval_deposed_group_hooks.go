@@ -0,0 +1,102 @@
+package terraform
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/states"
+)
+
+// deposedHookOp selects which pair of Hook callbacks
+// EvalCoalescedDeposedHooks fires.
+type deposedHookOp int
+
+const (
+	deposedHookRefresh deposedHookOp = iota
+	deposedHookDestroy
+)
+
+// EvalCoalescedDeposedHooks is an EvalNode implementation that reports a
+// graphNodeDeposedResourceGroup's per-key refresh/destroy results to the
+// configured Hook in one deterministic, single-threaded pass, once the
+// group's EvalParallel step has finished.
+//
+// Calling back into the Hook from inside each concurrently-running child
+// would let unrelated deposed keys interleave their Pre/Post notifications
+// with each other; coalescing the calls here instead means a Hook
+// implementation (such as the UI or JSON log hook) always sees one deposed
+// key's Pre/Post pair fully reported before the next one starts, and sees
+// exactly one state-update notification for the whole group rather than
+// one per key.
+type EvalCoalescedDeposedHooks struct {
+	Addr addrs.ResourceInstance
+	Keys []states.DeposedKey
+
+	// PriorStates and NewStates are parallel to Keys, populated by the
+	// per-key EvalSequences that EvalParallel already ran.
+	PriorStates []*states.ResourceInstanceObject
+	NewStates   []*states.ResourceInstanceObject
+
+	Op deposedHookOp
+
+	// UpdateStateHook, if true, additionally invokes the generic
+	// EvalUpdateStateHook-equivalent notification exactly once for the
+	// whole group after all per-key Pre/Post pairs have been reported.
+	UpdateStateHook bool
+}
+
+func (n *EvalCoalescedDeposedHooks) Eval(ctx EvalContext) (interface{}, error) {
+	absAddr := n.Addr.Absolute(ctx.Path())
+
+	order := make([]int, len(n.Keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return n.Keys[order[a]] < n.Keys[order[b]]
+	})
+
+	for _, i := range order {
+		key := n.Keys[i]
+		var prior, newState *states.ResourceInstanceObject
+		if i < len(n.PriorStates) {
+			prior = n.PriorStates[i]
+		}
+		if i < len(n.NewStates) {
+			newState = n.NewStates[i]
+		}
+
+		var err error
+		switch n.Op {
+		case deposedHookRefresh:
+			err = ctx.Hook(func(h Hook) (HookAction, error) {
+				return h.PreDeposedRefresh(absAddr, key, prior)
+			})
+			if err == nil {
+				err = ctx.Hook(func(h Hook) (HookAction, error) {
+					return h.PostDeposedRefresh(absAddr, key, newState)
+				})
+			}
+		case deposedHookDestroy:
+			err = ctx.Hook(func(h Hook) (HookAction, error) {
+				return h.PreDeposedDestroy(absAddr, key, prior)
+			})
+			if err == nil {
+				err = ctx.Hook(func(h Hook) (HookAction, error) {
+					return h.PostDeposedDestroy(absAddr, key, newState)
+				})
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if n.UpdateStateHook {
+		if _, err := (&EvalUpdateStateHook{}).Eval(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
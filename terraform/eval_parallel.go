@@ -0,0 +1,88 @@
+package terraform
+
+import (
+	"sync"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// EvalParallel is an EvalNode implementation that evaluates a fixed set of
+// child EvalNodes concurrently, bounded by Concurrency, and aggregates any
+// errors they return into a single multierror-style result.
+//
+// Unlike EvalSequence, the child nodes are assumed to be independent of one
+// another: EvalParallel makes no ordering guarantees between them, and a
+// failure in one child does not prevent the others from running to
+// completion.
+//
+// Each child is evaluated against its own EvalContext obtained via
+// ctx.WithPath, rather than the shared ctx itself: a single vertex's
+// EvalTree is normally walked single-threaded, so the per-vertex caches a
+// context implementation may keep (for example a resolved provider
+// instance) are not documented as safe for concurrent use. Giving every
+// child its own context sidesteps that question entirely, the same way the
+// graph walker already does when it evaluates independent vertices of the
+// same module concurrently.
+type EvalParallel struct {
+	Nodes []EvalNode
+
+	// Concurrency bounds how many Nodes may be evaluated at once. A value
+	// of zero or less means unbounded concurrency. Ignored if
+	// ConcurrencyPtr is non-nil and points at a positive value.
+	Concurrency int
+
+	// ConcurrencyPtr, if non-nil, is read at Eval time (after any
+	// preceding node in the same EvalSequence has had a chance to
+	// populate it) and takes precedence over Concurrency when positive.
+	// This lets the concurrency bound be decided dynamically, such as
+	// from a provider's declared max concurrency, which isn't known until
+	// the provider has actually been fetched.
+	ConcurrencyPtr *int
+}
+
+func (n *EvalParallel) Eval(ctx EvalContext) (interface{}, error) {
+	if len(n.Nodes) == 0 {
+		return nil, nil
+	}
+
+	limit := n.Concurrency
+	if n.ConcurrencyPtr != nil && *n.ConcurrencyPtr > 0 {
+		limit = *n.ConcurrencyPtr
+	}
+	if limit <= 0 || limit > len(n.Nodes) {
+		limit = len(n.Nodes)
+	}
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(n.Nodes))
+
+	for i, node := range n.Nodes {
+		if node == nil {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, node EvalNode) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			childCtx := ctx.WithPath(ctx.Path())
+			if _, err := node.Eval(childCtx); err != nil {
+				errs[i] = err
+			}
+		}(i, node)
+	}
+
+	wg.Wait()
+
+	var result error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		result = multierror.Append(result, err)
+	}
+	return nil, result
+}
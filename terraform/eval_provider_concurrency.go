@@ -0,0 +1,42 @@
+package terraform
+
+import "github.com/hashicorp/terraform/providers"
+
+// ProviderMaxConcurrencyDeclarer is an optional interface a provider plugin
+// may implement to declare how many of its resource instances may safely be
+// operated on concurrently within a single Terraform run. Providers that
+// don't implement it (which is every provider known to Terraform today) get
+// the group's MaxConcurrency, or defaultDeposedGroupConcurrency when that
+// is zero.
+type ProviderMaxConcurrencyDeclarer interface {
+	ProviderMaxConcurrency() int
+}
+
+// EvalProviderDeclaredConcurrency is an EvalNode implementation that reads
+// the concurrency bound a provider has declared for itself, falling back to
+// Default if the provider hasn't declared one (or declared a non-positive
+// value).
+type EvalProviderDeclaredConcurrency struct {
+	Provider *providers.Interface
+	Default  int
+
+	// Output is written with the resolved concurrency bound.
+	Output *int
+}
+
+func (n *EvalProviderDeclaredConcurrency) Eval(ctx EvalContext) (interface{}, error) {
+	concurrency := n.Default
+
+	if n.Provider != nil && *n.Provider != nil {
+		if declarer, ok := (*n.Provider).(ProviderMaxConcurrencyDeclarer); ok {
+			if declared := declarer.ProviderMaxConcurrency(); declared > 0 {
+				concurrency = declared
+			}
+		}
+	}
+
+	if n.Output != nil {
+		*n.Output = concurrency
+	}
+	return nil, nil
+}
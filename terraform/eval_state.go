@@ -0,0 +1,94 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/hashicorp/terraform/states"
+)
+
+// EvalReadStateDeposed is an EvalNode implementation that reads the deposed
+// instance object for a specific ResourceInstance out of state, along with
+// its retention bookkeeping.
+type EvalReadStateDeposed struct {
+	Addr           addrs.ResourceInstance
+	Key            states.DeposedKey
+	Provider       *providers.Interface
+	ProviderSchema **ProviderSchema
+
+	// Output will be written with a pointer to the retrieved object, or
+	// nil if no object was found for the given key.
+	Output **states.ResourceInstanceObject
+
+	// Attempt will be written with the attempt-tracking record associated
+	// with this deposed key, so that retention policy can be evaluated
+	// against it. A zero-value record is produced if none has been
+	// recorded yet.
+	Attempt *states.DeposedAttemptRecord
+}
+
+func (n *EvalReadStateDeposed) Eval(ctx EvalContext) (interface{}, error) {
+	return n.readResourceInstanceObjectForDeposed(ctx)
+}
+
+func (n *EvalReadStateDeposed) readResourceInstanceObjectForDeposed(ctx EvalContext) (interface{}, error) {
+	if n.Addr.Resource.Mode != addrs.ManagedResourceMode {
+		return nil, fmt.Errorf("can only read deposed state for managed resources, not %s", n.Addr.Resource.Mode)
+	}
+
+	absAddr := n.Addr.Absolute(ctx.Path())
+	state := ctx.State().DeposedInstanceObject(absAddr, n.Key)
+	if n.Output != nil {
+		*n.Output = state
+	}
+	if n.Attempt != nil {
+		*n.Attempt = ctx.State().DeposedAttemptRecord(absAddr, n.Key)
+	}
+	return nil, nil
+}
+
+// EvalWriteStateDeposed is an EvalNode implementation that writes a deposed
+// instance object for a specific ResourceInstance into state, along with
+// its retention bookkeeping.
+type EvalWriteStateDeposed struct {
+	Addr           addrs.ResourceInstance
+	Key            states.DeposedKey
+	ProviderAddr   addrs.AbsProviderConfig
+	ProviderSchema **ProviderSchema
+
+	// State is the object to write, or a pointer to a nil object to
+	// delete the deposed object from state entirely (e.g. because the
+	// destroy succeeded).
+	State **states.ResourceInstanceObject
+
+	// Policy, if non-nil, is recorded alongside State so that a future run
+	// reading this deposed object back can evaluate the same retention
+	// policy against its attempt history.
+	Policy *states.DeposedRetentionPolicy
+
+	// Attempt, if non-nil, is recorded alongside State so that retention
+	// policy decisions made on a subsequent run can see how many times
+	// this deposed object has already been attempted.
+	Attempt *states.DeposedAttemptRecord
+}
+
+func (n *EvalWriteStateDeposed) Eval(ctx EvalContext) (interface{}, error) {
+	if n.Addr.Resource.Mode != addrs.ManagedResourceMode {
+		return nil, fmt.Errorf("can only write deposed state for managed resources, not %s", n.Addr.Resource.Mode)
+	}
+
+	absAddr := n.Addr.Absolute(ctx.Path())
+	state := ctx.State()
+
+	if n.State == nil || *n.State == nil {
+		state.ForgetResourceInstanceDeposed(absAddr, n.Key)
+		return nil, nil
+	}
+
+	state.SetResourceInstanceDeposed(absAddr, n.Key, *n.State, n.Policy, n.ProviderAddr)
+	if n.Attempt != nil {
+		state.SetDeposedAttemptRecord(absAddr, n.Key, *n.Attempt)
+	}
+	return nil, nil
+}
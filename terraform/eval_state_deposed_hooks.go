@@ -0,0 +1,85 @@
+package terraform
+
+import (
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/states"
+)
+
+// EvalPreDeposedRefresh and its Post/Destroy siblings below are EvalNode
+// implementations that notify the configured Hook about deposed-object
+// refresh/destroy activity specifically, via the PreDeposedRefresh /
+// PostDeposedRefresh / PreDeposedDestroy / PostDeposedDestroy callbacks,
+// rather than relying on the generic EvalUpdateStateHook to cover this case
+// opaquely.
+
+type EvalPreDeposedRefresh struct {
+	Addr  addrs.ResourceInstance
+	Key   states.DeposedKey
+	State **states.ResourceInstanceObject
+}
+
+func (n *EvalPreDeposedRefresh) Eval(ctx EvalContext) (interface{}, error) {
+	absAddr := n.Addr.Absolute(ctx.Path())
+	var state *states.ResourceInstanceObject
+	if n.State != nil {
+		state = *n.State
+	}
+
+	return nil, ctx.Hook(func(h Hook) (HookAction, error) {
+		return h.PreDeposedRefresh(absAddr, n.Key, state)
+	})
+}
+
+type EvalPostDeposedRefresh struct {
+	Addr  addrs.ResourceInstance
+	Key   states.DeposedKey
+	State **states.ResourceInstanceObject
+}
+
+func (n *EvalPostDeposedRefresh) Eval(ctx EvalContext) (interface{}, error) {
+	absAddr := n.Addr.Absolute(ctx.Path())
+	var state *states.ResourceInstanceObject
+	if n.State != nil {
+		state = *n.State
+	}
+
+	return nil, ctx.Hook(func(h Hook) (HookAction, error) {
+		return h.PostDeposedRefresh(absAddr, n.Key, state)
+	})
+}
+
+type EvalPreDeposedDestroy struct {
+	Addr  addrs.ResourceInstance
+	Key   states.DeposedKey
+	State **states.ResourceInstanceObject
+}
+
+func (n *EvalPreDeposedDestroy) Eval(ctx EvalContext) (interface{}, error) {
+	absAddr := n.Addr.Absolute(ctx.Path())
+	var state *states.ResourceInstanceObject
+	if n.State != nil {
+		state = *n.State
+	}
+
+	return nil, ctx.Hook(func(h Hook) (HookAction, error) {
+		return h.PreDeposedDestroy(absAddr, n.Key, state)
+	})
+}
+
+type EvalPostDeposedDestroy struct {
+	Addr  addrs.ResourceInstance
+	Key   states.DeposedKey
+	State **states.ResourceInstanceObject
+}
+
+func (n *EvalPostDeposedDestroy) Eval(ctx EvalContext) (interface{}, error) {
+	absAddr := n.Addr.Absolute(ctx.Path())
+	var state *states.ResourceInstanceObject
+	if n.State != nil {
+		state = *n.State
+	}
+
+	return nil, ctx.Hook(func(h Hook) (HookAction, error) {
+		return h.PostDeposedDestroy(absAddr, n.Key, state)
+	})
+}
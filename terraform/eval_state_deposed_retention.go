@@ -0,0 +1,85 @@
+package terraform
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/states"
+)
+
+// EvalCheckDeposedRetention is an EvalNode implementation that consults a
+// DeposedRetentionPolicy before a deposed object's destroy subsequence
+// continues.
+//
+// A policy denial is not automatically fatal: a deposed object that is
+// merely within its MinBackoff window is skipped for this run only, since
+// it will naturally become eligible again on a later run once the backoff
+// interval elapses. Only a denial that Policy.Expired agrees with (the
+// object has exceeded MaxAge or MaxAttempts) is treated as fatal, since no
+// later run will ever allow it again without operator intervention.
+type EvalCheckDeposedRetention struct {
+	Addr    addrs.ResourceInstance
+	Key     states.DeposedKey
+	Policy  *states.DeposedRetentionPolicy
+	Attempt *states.DeposedAttemptRecord
+
+	// Skip, if non-nil, is set to true when the policy denied this attempt
+	// without Expired also agreeing, meaning the rest of this run's destroy
+	// subsequence should be skipped rather than treated as an error.
+	Skip *bool
+
+	// Error, if non-nil, receives a fatal retention-exceeded error instead
+	// of it being returned directly, so that later cleanup/hook nodes in
+	// the same EvalSequence still run before it ultimately surfaces via
+	// EvalReturnError.
+	Error *error
+}
+
+// EvalRecordDeposedAttempt is an EvalNode implementation that updates a
+// DeposedAttemptRecord to reflect that a destroy attempt has just been
+// made, ready to be persisted by a following EvalWriteStateDeposed.
+type EvalRecordDeposedAttempt struct {
+	Attempt *states.DeposedAttemptRecord
+}
+
+func (n *EvalRecordDeposedAttempt) Eval(ctx EvalContext) (interface{}, error) {
+	if n.Attempt != nil {
+		*n.Attempt = n.Attempt.RecordAttempt(time.Now())
+	}
+	return nil, nil
+}
+
+func (n *EvalCheckDeposedRetention) Eval(ctx EvalContext) (interface{}, error) {
+	if n.Skip != nil {
+		*n.Skip = false
+	}
+	if n.Policy == nil || n.Attempt == nil {
+		return nil, nil
+	}
+
+	now := time.Now()
+	if n.Policy.Allow(*n.Attempt, now) {
+		return nil, nil
+	}
+
+	if n.Skip != nil {
+		*n.Skip = true
+	}
+
+	if !n.Policy.Expired(*n.Attempt, now) {
+		// Denied only by MinBackoff: leave the attempt record untouched and
+		// try again on a later run.
+		return nil, nil
+	}
+
+	err := fmt.Errorf(
+		"deposed object %s (%s) has exceeded its retention policy after %d attempt(s); run \"terraform state rm-deposed --expired\" to remove it",
+		n.Addr, n.Key, n.Attempt.Attempts,
+	)
+	if n.Error != nil {
+		*n.Error = err
+		return nil, nil
+	}
+	return nil, err
+}
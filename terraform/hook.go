@@ -0,0 +1,58 @@
+package terraform
+
+import (
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/states"
+)
+
+// HookAction is an enum of actions that can be taken as a result of a hook
+// callback. This allows hooks to modify the behavior of Terraform.
+type HookAction byte
+
+const (
+	// HookActionContinue continues with processing as usual.
+	HookActionContinue HookAction = iota
+
+	// HookActionHalt halts immediately: no more hooks are processed and
+	// the action that Terraform was about to take is cancelled.
+	HookActionHalt
+)
+
+// Hook is the interface that must be implemented to hook into various
+// parts of Terraform, allowing you to inspect or change behavior at
+// runtime.
+//
+// This tree only models the subset of Hook exercised by deposed resource
+// instance object handling; a full Hook implementation has many more
+// methods covering the rest of a graph walk (apply, diff, provisioning,
+// state updates, and so on).
+//
+// Deposed objects batched into a graphNodeDeposedResourceGroup are reported
+// differently from a single graphNodeDeposedResource: each key's Pre and
+// Post callbacks are both made after the whole group's refresh or destroy
+// work has finished (so HookActionHalt from a Pre callback cancels nothing
+// for that group), keys whose destroy was skipped for backoff are reported
+// too, and no callbacks are made for the group if any of its keys failed.
+type Hook interface {
+	// PreDeposedRefresh is called before a deposed object is refreshed.
+	PreDeposedRefresh(addr addrs.AbsResourceInstance, key states.DeposedKey, priorState *states.ResourceInstanceObject) (HookAction, error)
+
+	// PostDeposedRefresh is called after a deposed object has been
+	// refreshed.
+	PostDeposedRefresh(addr addrs.AbsResourceInstance, key states.DeposedKey, newState *states.ResourceInstanceObject) (HookAction, error)
+
+	// PreDeposedDestroy is called before a deposed object is destroyed.
+	PreDeposedDestroy(addr addrs.AbsResourceInstance, key states.DeposedKey, priorState *states.ResourceInstanceObject) (HookAction, error)
+
+	// PostDeposedDestroy is called after a deposed object destroy attempt
+	// has completed, regardless of whether it succeeded. newState is nil
+	// if the object was successfully destroyed and pruned from state.
+	PostDeposedDestroy(addr addrs.AbsResourceInstance, key states.DeposedKey, newState *states.ResourceInstanceObject) (HookAction, error)
+}
+
+// NilHook is a Hook implementation that does nothing. Embed it in a
+// concrete Hook implementation to only override the callbacks you care
+// about.
+type NilHook struct{}
+
+var _ Hook = (*NilHook)(nil)
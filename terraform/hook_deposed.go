@@ -0,0 +1,26 @@
+package terraform
+
+import (
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/states"
+)
+
+// PreDeposedRefresh implements Hook.
+func (*NilHook) PreDeposedRefresh(addr addrs.AbsResourceInstance, key states.DeposedKey, priorState *states.ResourceInstanceObject) (HookAction, error) {
+	return HookActionContinue, nil
+}
+
+// PostDeposedRefresh implements Hook.
+func (*NilHook) PostDeposedRefresh(addr addrs.AbsResourceInstance, key states.DeposedKey, newState *states.ResourceInstanceObject) (HookAction, error) {
+	return HookActionContinue, nil
+}
+
+// PreDeposedDestroy implements Hook.
+func (*NilHook) PreDeposedDestroy(addr addrs.AbsResourceInstance, key states.DeposedKey, priorState *states.ResourceInstanceObject) (HookAction, error) {
+	return HookActionContinue, nil
+}
+
+// PostDeposedDestroy implements Hook.
+func (*NilHook) PostDeposedDestroy(addr addrs.AbsResourceInstance, key states.DeposedKey, newState *states.ResourceInstanceObject) (HookAction, error) {
+	return HookActionContinue, nil
+}
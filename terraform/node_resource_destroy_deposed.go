@@ -15,6 +15,16 @@ import (
 type graphNodeDeposedResource struct {
 	*NodeAbstractResourceInstance
 	DeposedKey states.DeposedKey
+
+	// RetentionPolicy, if non-nil, bounds how long and how many times
+	// Terraform will keep retrying destruction of this deposed object
+	// before refusing to try again. A nil policy preserves the historical
+	// behavior of retrying indefinitely.
+	//
+	// Nothing constructs this node with a non-nil RetentionPolicy yet: see
+	// DeposedGroupTransformer.RetentionPolicy for where that follow-up
+	// wiring belongs.
+	RetentionPolicy *states.DeposedRetentionPolicy
 }
 
 var (
@@ -75,6 +85,7 @@ func (n *graphNodeDeposedResource) EvalTree() EvalNode {
 	var provider providers.Interface
 	var providerSchema *ProviderSchema
 	var state *states.ResourceInstanceObject
+	var attempt states.DeposedAttemptRecord
 
 	seq := &EvalSequence{Nodes: make([]EvalNode, 0, 5)}
 
@@ -94,6 +105,11 @@ func (n *graphNodeDeposedResource) EvalTree() EvalNode {
 					Key:            n.DeposedKey,
 					Output:         &state,
 				},
+				&EvalPreDeposedRefresh{
+					Addr:  addr.Resource,
+					Key:   n.DeposedKey,
+					State: &state,
+				},
 				&EvalRefresh{
 					Addr:           addr.Resource,
 					ProviderAddr:   n.ResolvedProvider,
@@ -109,6 +125,11 @@ func (n *graphNodeDeposedResource) EvalTree() EvalNode {
 					ProviderSchema: &providerSchema,
 					State:          &state,
 				},
+				&EvalPostDeposedRefresh{
+					Addr:  addr.Resource,
+					Key:   n.DeposedKey,
+					State: &state,
+				},
 			},
 		},
 	})
@@ -116,6 +137,7 @@ func (n *graphNodeDeposedResource) EvalTree() EvalNode {
 	// Apply
 	var change *plans.ResourceInstanceChange
 	var err error
+	var skipDestroy bool
 	seq.Nodes = append(seq.Nodes, &EvalOpFilter{
 		Ops: []walkOperation{walkApply, walkDestroy},
 		Node: &EvalSequence{
@@ -131,43 +153,84 @@ func (n *graphNodeDeposedResource) EvalTree() EvalNode {
 					Key:            n.DeposedKey,
 					Provider:       &provider,
 					ProviderSchema: &providerSchema,
+					Attempt:        &attempt,
 				},
-				&EvalDiffDestroy{
-					Addr:   addr.Resource,
-					State:  &state,
-					Output: &change,
+				// Refuse to retry a deposed object that has exhausted its
+				// retention policy, rather than attempting destruction
+				// indefinitely. A denial that's only due to MinBackoff
+				// instead skips this run via skipDestroy, to be retried
+				// automatically on a later run.
+				&EvalCheckDeposedRetention{
+					Addr:    addr.Resource,
+					Key:     n.DeposedKey,
+					Policy:  n.RetentionPolicy,
+					Attempt: &attempt,
+					Skip:    &skipDestroy,
+					Error:   &err,
 				},
-				// Call pre-apply hook
-				&EvalApplyPre{
-					Addr:   addr.Resource,
-					State:  &state,
-					Change: &change,
-				},
-				&EvalApply{
-					Addr:           addr.Resource,
-					Config:         nil, // No configuration because we are destroying
-					State:          &state,
-					Change:         &change,
-					Provider:       &provider,
-					ProviderAddr:   n.ResolvedProvider,
-					ProviderSchema: &providerSchema,
-					Output:         &state,
-					Error:          &err,
-				},
-				// Always write the resource back to the state deposed... if it
-				// was successfully destroyed it will be pruned. If it was not, it will
-				// be caught on the next run.
-				&EvalWriteStateDeposed{
-					Addr:           addr.Resource,
-					Key:            n.DeposedKey,
-					ProviderAddr:   n.ResolvedProvider,
-					ProviderSchema: &providerSchema,
-					State:          &state,
-				},
-				&EvalApplyPost{
-					Addr:  addr.Resource,
-					State: &state,
-					Error: &err,
+				&EvalIf{
+					If: func(ctx EvalContext) (bool, error) {
+						return !skipDestroy, nil
+					},
+					Then: &EvalSequence{
+						Nodes: []EvalNode{
+							&EvalDiffDestroy{
+								Addr:   addr.Resource,
+								State:  &state,
+								Output: &change,
+							},
+							&EvalPreDeposedDestroy{
+								Addr:  addr.Resource,
+								Key:   n.DeposedKey,
+								State: &state,
+							},
+							// Call pre-apply hook
+							&EvalApplyPre{
+								Addr:   addr.Resource,
+								State:  &state,
+								Change: &change,
+							},
+							&EvalApply{
+								Addr:           addr.Resource,
+								Config:         nil, // No configuration because we are destroying
+								State:          &state,
+								Change:         &change,
+								Provider:       &provider,
+								ProviderAddr:   n.ResolvedProvider,
+								ProviderSchema: &providerSchema,
+								Output:         &state,
+								Error:          &err,
+							},
+							&EvalRecordDeposedAttempt{
+								Attempt: &attempt,
+							},
+							// Always write the resource back to the state deposed... if it
+							// was successfully destroyed it will be pruned. If it was not, it will
+							// be caught on the next run, bounded by RetentionPolicy above.
+							&EvalWriteStateDeposed{
+								Addr:           addr.Resource,
+								Key:            n.DeposedKey,
+								ProviderAddr:   n.ResolvedProvider,
+								ProviderSchema: &providerSchema,
+								State:          &state,
+								Policy:         n.RetentionPolicy,
+								Attempt:        &attempt,
+							},
+							// Report the deposed destroy before EvalApplyPost,
+							// which returns any destroy error and so would end
+							// this sequence before a failed attempt is reported.
+							&EvalPostDeposedDestroy{
+								Addr:  addr.Resource,
+								Key:   n.DeposedKey,
+								State: &state,
+							},
+							&EvalApplyPost{
+								Addr:  addr.Resource,
+								State: &state,
+								Error: &err,
+							},
+						},
+					},
 				},
 				&EvalReturnError{
 					Error: &err,
@@ -0,0 +1,338 @@
+package terraform
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/plans"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/hashicorp/terraform/states"
+)
+
+// defaultDeposedGroupConcurrency bounds how many deposed objects belonging
+// to the same resource instance are refreshed/destroyed at once when the
+// provider has not declared an explicit limit.
+const defaultDeposedGroupConcurrency = 10
+
+// graphNodeDeposedResourceGroup is the graph vertex representing every
+// deposed object associated with a single resource instance. Deposed
+// objects only exist because create_before_destroy left them behind, so by
+// construction they have no dependencies on one another: batching them
+// into one vertex lets their refresh/destroy EvalSequences run
+// concurrently instead of being serialized through graph edges.
+type graphNodeDeposedResourceGroup struct {
+	*NodeAbstractResourceInstance
+
+	// DeposedKeys are the deposed objects batched into this group, in no
+	// particular order; Name() and EvalCoalescedDeposedHooks sort them so
+	// that their output is deterministic.
+	DeposedKeys []states.DeposedKey
+
+	// RetentionPolicy, if non-nil, is applied independently to each
+	// deposed object in the group, exactly as it would be for a single
+	// graphNodeDeposedResource.
+	RetentionPolicy *states.DeposedRetentionPolicy
+
+	// MaxConcurrency bounds how many of the group's deposed objects are
+	// processed at once when the provider does not declare its own max
+	// concurrency; a positive provider-declared value takes precedence (see
+	// EvalProviderDeclaredConcurrency). Zero means
+	// defaultDeposedGroupConcurrency.
+	MaxConcurrency int
+}
+
+var (
+	_ GraphNodeResource            = (*graphNodeDeposedResourceGroup)(nil)
+	_ GraphNodeResourceInstance    = (*graphNodeDeposedResourceGroup)(nil)
+	_ GraphNodeDestroyer           = (*graphNodeDeposedResourceGroup)(nil)
+	_ GraphNodeDestroyerCBD        = (*graphNodeDeposedResourceGroup)(nil)
+	_ GraphNodeReferenceable       = (*graphNodeDeposedResourceGroup)(nil)
+	_ GraphNodeReferencer          = (*graphNodeDeposedResourceGroup)(nil)
+	_ GraphNodeEvalable            = (*graphNodeDeposedResourceGroup)(nil)
+	_ GraphNodeProviderConsumer    = (*graphNodeDeposedResourceGroup)(nil)
+	_ GraphNodeProvisionerConsumer = (*graphNodeDeposedResourceGroup)(nil)
+)
+
+func (n *graphNodeDeposedResourceGroup) Name() string {
+	keys := make([]string, len(n.DeposedKeys))
+	for i, k := range n.DeposedKeys {
+		keys[i] = string(k)
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf("%s (deposed %v)", n.Addr.String(), keys)
+}
+
+// GraphNodeReferenceable implementation
+func (n *graphNodeDeposedResourceGroup) ReferenceableAddrs() []addrs.Referenceable {
+	// Deposed objects don't participate in references.
+	return nil
+}
+
+// GraphNodeReferencer implementation
+func (n *graphNodeDeposedResourceGroup) References() []*addrs.Reference {
+	// We don't evaluate configuration for deposed objects, so they
+	// effectively make no references. Grouping them doesn't change that,
+	// but the group vertex still participates in the same destroy-order
+	// edges a single graphNodeDeposedResource would, so its ordering
+	// against the non-deposed instance node it was deposed from is
+	// unchanged.
+	return nil
+}
+
+// GraphNodeDestroyer
+func (n *graphNodeDeposedResourceGroup) DestroyAddr() *addrs.AbsResourceInstance {
+	addr := n.ResourceInstanceAddr()
+	return &addr
+}
+
+// GraphNodeDestroyerCBD
+func (n *graphNodeDeposedResourceGroup) CreateBeforeDestroy() bool {
+	// A deposed instance is always CreateBeforeDestroy by definition, since
+	// we use deposed only to handle create-before-destroy.
+	return true
+}
+
+// GraphNodeDestroyerCBD
+func (n *graphNodeDeposedResourceGroup) ModifyCreateBeforeDestroy(v bool) error {
+	if !v {
+		// Should never happen: deposed instances are _always_ create_before_destroy.
+		return fmt.Errorf("can't deactivate create_before_destroy for a deposed instance")
+	}
+	return nil
+}
+
+func (n *graphNodeDeposedResourceGroup) concurrency() int {
+	if n.MaxConcurrency > 0 {
+		return n.MaxConcurrency
+	}
+	return defaultDeposedGroupConcurrency
+}
+
+// GraphNodeEvalable impl.
+//
+// Each phase (refresh, apply/destroy) fetches the provider once up front to
+// resolve its declared concurrency (see EvalProviderDeclaredConcurrency),
+// runs every deposed key's work through EvalParallel bounded by that
+// concurrency, and then reports the batch's Hook callbacks in one
+// deterministic, single-threaded pass via EvalCoalescedDeposedHooks. Hooks
+// are deliberately not fired from inside the per-key EvalSequences: doing
+// so would let unrelated keys' notifications interleave on the shared Hook.
+func (n *graphNodeDeposedResourceGroup) EvalTree() EvalNode {
+	addr := n.ResourceInstanceAddr()
+	count := len(n.DeposedKeys)
+
+	refreshPrior := make([]*states.ResourceInstanceObject, count)
+	refreshNew := make([]*states.ResourceInstanceObject, count)
+	applyPrior := make([]*states.ResourceInstanceObject, count)
+	applyNew := make([]*states.ResourceInstanceObject, count)
+
+	refreshNodes := make([]EvalNode, count)
+	applyNodes := make([]EvalNode, count)
+
+	for i, key := range n.DeposedKeys {
+		refreshNodes[i] = n.evalRefreshOne(addr, key, &refreshPrior[i], &refreshNew[i])
+		applyNodes[i] = n.evalApplyOne(addr, key, &applyPrior[i], &applyNew[i])
+	}
+
+	var refreshProvider, applyProvider providers.Interface
+	var refreshConcurrency, applyConcurrency int
+
+	seq := &EvalSequence{Nodes: make([]EvalNode, 0, 2)}
+
+	seq.Nodes = append(seq.Nodes, &EvalOpFilter{
+		Ops: []walkOperation{walkRefresh},
+		Node: &EvalSequence{Nodes: []EvalNode{
+			&EvalGetProvider{Addr: n.ResolvedProvider, Output: &refreshProvider},
+			&EvalProviderDeclaredConcurrency{
+				Provider: &refreshProvider,
+				Default:  n.concurrency(),
+				Output:   &refreshConcurrency,
+			},
+			&EvalParallel{Nodes: refreshNodes, ConcurrencyPtr: &refreshConcurrency},
+			&EvalCoalescedDeposedHooks{
+				Addr:        addr.Resource,
+				Keys:        n.DeposedKeys,
+				PriorStates: refreshPrior,
+				NewStates:   refreshNew,
+				Op:          deposedHookRefresh,
+			},
+		}},
+	})
+
+	seq.Nodes = append(seq.Nodes, &EvalOpFilter{
+		Ops: []walkOperation{walkApply, walkDestroy},
+		Node: &EvalSequence{Nodes: []EvalNode{
+			&EvalGetProvider{Addr: n.ResolvedProvider, Output: &applyProvider},
+			&EvalProviderDeclaredConcurrency{
+				Provider: &applyProvider,
+				Default:  n.concurrency(),
+				Output:   &applyConcurrency,
+			},
+			&EvalParallel{Nodes: applyNodes, ConcurrencyPtr: &applyConcurrency},
+			&EvalCoalescedDeposedHooks{
+				Addr:            addr.Resource,
+				Keys:            n.DeposedKeys,
+				PriorStates:     applyPrior,
+				NewStates:       applyNew,
+				Op:              deposedHookDestroy,
+				UpdateStateHook: true,
+			},
+		}},
+	})
+
+	return seq
+}
+
+// evalCaptureDeposedState is an EvalNode implementation that copies the
+// current value of *State into *Output, for use between steps of a deposed
+// object's EvalSequence where a later step may go on to reassign State.
+type evalCaptureDeposedState struct {
+	State  **states.ResourceInstanceObject
+	Output **states.ResourceInstanceObject
+}
+
+func (n *evalCaptureDeposedState) Eval(ctx EvalContext) (interface{}, error) {
+	*n.Output = *n.State
+	return nil, nil
+}
+
+// evalRefreshOne builds the refresh EvalSequence for a single deposed key
+// in the group, equivalent to the walkRefresh branch of
+// graphNodeDeposedResource.EvalTree. priorOut and newOut are populated with
+// the object's state immediately before and after the refresh, for the
+// group's coalesced hook reporting.
+func (n *graphNodeDeposedResourceGroup) evalRefreshOne(addr addrs.AbsResourceInstance, key states.DeposedKey, priorOut, newOut **states.ResourceInstanceObject) EvalNode {
+	var provider providers.Interface
+	var providerSchema *ProviderSchema
+	var state *states.ResourceInstanceObject
+
+	return &EvalSequence{
+		Nodes: []EvalNode{
+			&EvalGetProvider{
+				Addr:   n.ResolvedProvider,
+				Output: &provider,
+				Schema: &providerSchema,
+			},
+			&EvalReadStateDeposed{
+				Addr:           addr.Resource,
+				ProviderSchema: &providerSchema,
+				Key:            key,
+				Output:         &state,
+			},
+			&evalCaptureDeposedState{State: &state, Output: priorOut},
+			&EvalRefresh{
+				Addr:           addr.Resource,
+				ProviderAddr:   n.ResolvedProvider,
+				Provider:       &provider,
+				ProviderSchema: &providerSchema,
+				State:          &state,
+				Output:         &state,
+			},
+			&EvalWriteStateDeposed{
+				Addr:           addr.Resource,
+				Key:            key,
+				ProviderAddr:   n.ResolvedProvider,
+				ProviderSchema: &providerSchema,
+				State:          &state,
+			},
+			&evalCaptureDeposedState{State: &state, Output: newOut},
+		},
+	}
+}
+
+// evalApplyOne builds the apply/destroy EvalSequence for a single deposed
+// key in the group, equivalent to the walkApply/walkDestroy branch of
+// graphNodeDeposedResource.EvalTree. priorOut and newOut are populated with
+// the object's state immediately before and after the destroy attempt, for
+// the group's coalesced hook reporting.
+func (n *graphNodeDeposedResourceGroup) evalApplyOne(addr addrs.AbsResourceInstance, key states.DeposedKey, priorOut, newOut **states.ResourceInstanceObject) EvalNode {
+	var provider providers.Interface
+	var providerSchema *ProviderSchema
+	var state *states.ResourceInstanceObject
+	var attempt states.DeposedAttemptRecord
+	var change *plans.ResourceInstanceChange
+	var err error
+	var skipDestroy bool
+
+	return &EvalSequence{
+		Nodes: []EvalNode{
+			&EvalGetProvider{
+				Addr:   n.ResolvedProvider,
+				Output: &provider,
+				Schema: &providerSchema,
+			},
+			&EvalReadStateDeposed{
+				Addr:           addr.Resource,
+				Output:         &state,
+				Key:            key,
+				Provider:       &provider,
+				ProviderSchema: &providerSchema,
+				Attempt:        &attempt,
+			},
+			&evalCaptureDeposedState{State: &state, Output: priorOut},
+			// A denial that's only due to MinBackoff skips this key's
+			// destroy attempt for this run via skipDestroy, to be retried
+			// automatically on a later run.
+			&EvalCheckDeposedRetention{
+				Addr:    addr.Resource,
+				Key:     key,
+				Policy:  n.RetentionPolicy,
+				Attempt: &attempt,
+				Skip:    &skipDestroy,
+				Error:   &err,
+			},
+			&EvalIf{
+				If: func(ctx EvalContext) (bool, error) {
+					return !skipDestroy, nil
+				},
+				Then: &EvalSequence{
+					Nodes: []EvalNode{
+						&EvalDiffDestroy{
+							Addr:   addr.Resource,
+							State:  &state,
+							Output: &change,
+						},
+						&EvalApplyPre{
+							Addr:   addr.Resource,
+							State:  &state,
+							Change: &change,
+						},
+						&EvalApply{
+							Addr:           addr.Resource,
+							Config:         nil,
+							State:          &state,
+							Change:         &change,
+							Provider:       &provider,
+							ProviderAddr:   n.ResolvedProvider,
+							ProviderSchema: &providerSchema,
+							Output:         &state,
+							Error:          &err,
+						},
+						&EvalRecordDeposedAttempt{
+							Attempt: &attempt,
+						},
+						&EvalWriteStateDeposed{
+							Addr:           addr.Resource,
+							Key:            key,
+							ProviderAddr:   n.ResolvedProvider,
+							ProviderSchema: &providerSchema,
+							State:          &state,
+							Policy:         n.RetentionPolicy,
+							Attempt:        &attempt,
+						},
+						&EvalApplyPost{
+							Addr:  addr.Resource,
+							State: &state,
+							Error: &err,
+						},
+					},
+				},
+			},
+			&evalCaptureDeposedState{State: &state, Output: newOut},
+			&EvalReturnError{
+				Error: &err,
+			},
+		},
+	}
+}
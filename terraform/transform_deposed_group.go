@@ -0,0 +1,87 @@
+package terraform
+
+import (
+	"github.com/hashicorp/terraform/dag"
+	"github.com/hashicorp/terraform/states"
+)
+
+// DeposedGroupTransformer replaces the per-DeposedKey graphNodeDeposedResource
+// vertices that would otherwise be created for a resource instance's deposed
+// objects with a single graphNodeDeposedResourceGroup vertex, so that the
+// group's refresh/destroy work can run concurrently instead of being
+// serialized through normal graph edges between unrelated deposed keys.
+//
+// Deposed objects for a given instance are independent of one another by
+// construction: they only exist because create_before_destroy left them
+// behind while the instance itself moved on to a new object. Grouping them
+// does not change the edges a deposed vertex needs to the non-deposed
+// instance node it came from; it only changes how the deposed keys
+// belonging to one instance are scheduled relative to each other.
+type DeposedGroupTransformer struct {
+	// Concurrency bounds how many deposed objects in a group are processed
+	// at once when the provider does not declare its own max concurrency.
+	// Zero selects defaultDeposedGroupConcurrency.
+	Concurrency int
+
+	// RetentionPolicy, if non-nil, is applied to every group this
+	// transformer creates whose source graphNodeDeposedResource vertices
+	// didn't already carry one of their own.
+	//
+	// Nothing in this source tree slice constructs a graphNodeDeposedResource
+	// with a non-nil RetentionPolicy: that requires a config-driven
+	// transformer (reading something like a resource's `retain_deposed`
+	// settings) that isn't part of this tree slice, so per-vertex policies
+	// are always nil today. This field exists as the wiring point for that
+	// follow-up: once real per-resource policies are attached to
+	// graphNodeDeposedResource, they'll take precedence here; until then,
+	// RetentionPolicy is this transformer's only source of a non-nil
+	// policy, and retention enforcement stays a no-op unless a caller sets
+	// it explicitly.
+	RetentionPolicy *states.DeposedRetentionPolicy
+}
+
+func (t *DeposedGroupTransformer) Transform(g *Graph) error {
+	groups := make(map[*NodeAbstractResourceInstance]*graphNodeDeposedResourceGroup)
+	var order []*NodeAbstractResourceInstance
+
+	for _, v := range g.Vertices() {
+		dn, ok := v.(*graphNodeDeposedResource)
+		if !ok {
+			continue
+		}
+
+		group, exists := groups[dn.NodeAbstractResourceInstance]
+		if !exists {
+			policy := dn.RetentionPolicy
+			if policy == nil {
+				policy = t.RetentionPolicy
+			}
+			group = &graphNodeDeposedResourceGroup{
+				NodeAbstractResourceInstance: dn.NodeAbstractResourceInstance,
+				RetentionPolicy:              policy,
+				MaxConcurrency:               t.Concurrency,
+			}
+			groups[dn.NodeAbstractResourceInstance] = group
+			order = append(order, dn.NodeAbstractResourceInstance)
+		}
+		group.DeposedKeys = append(group.DeposedKeys, dn.DeposedKey)
+
+		// Preserve whatever edges the per-key vertex had (most importantly
+		// the edge to the non-deposed instance node it was deposed from)
+		// by re-pointing them at the group vertex.
+		for _, edge := range g.EdgesFrom(v) {
+			g.Connect(dag.BasicEdge(group, edge.Target()))
+		}
+		for _, edge := range g.EdgesTo(v) {
+			g.Connect(dag.BasicEdge(edge.Source(), group))
+		}
+
+		g.Remove(v)
+	}
+
+	for _, key := range order {
+		g.Add(groups[key])
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/dag"
+	"github.com/hashicorp/terraform/states"
+)
+
+// TestDeposedGroupTransformer exercises DeposedGroupTransformer.Transform
+// directly against a hand-built graph, since this source tree doesn't
+// contain a concrete GraphBuilder (e.g. ApplyGraphBuilder) to register the
+// transformer into and drive end-to-end; the real repo's graph builders are
+// the place that ordering is wired up, and aren't part of this tree slice.
+func TestDeposedGroupTransformer(t *testing.T) {
+	rn := &NodeAbstractResourceInstance{}
+
+	dep1 := &graphNodeDeposedResource{
+		NodeAbstractResourceInstance: rn,
+		DeposedKey:                   states.DeposedKey("1111aaaa"),
+	}
+	dep2 := &graphNodeDeposedResource{
+		NodeAbstractResourceInstance: rn,
+		DeposedKey:                   states.DeposedKey("2222bbbb"),
+	}
+	instance := &NodeAbstractResourceInstance{}
+
+	g := &dag.AcyclicGraph{}
+	g.Add(dep1)
+	g.Add(dep2)
+	g.Add(instance)
+	g.Connect(dag.BasicEdge(dep1, instance))
+	g.Connect(dag.BasicEdge(dep2, instance))
+
+	graph := &Graph{AcyclicGraph: *g}
+
+	transformer := &DeposedGroupTransformer{}
+	if err := transformer.Transform(graph); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var group *graphNodeDeposedResourceGroup
+	for _, v := range graph.Vertices() {
+		if g, ok := v.(*graphNodeDeposedResourceGroup); ok {
+			group = g
+		}
+		if _, ok := v.(*graphNodeDeposedResource); ok {
+			t.Fatalf("per-key deposed vertex %v survived the transform", v)
+		}
+	}
+	if group == nil {
+		t.Fatal("no graphNodeDeposedResourceGroup vertex was created")
+	}
+	if got, want := len(group.DeposedKeys), 2; got != want {
+		t.Fatalf("wrong number of deposed keys in group: got %d, want %d", got, want)
+	}
+
+	edges := graph.EdgesFrom(group)
+	if len(edges) != 1 || edges[0].Target() != dag.Vertex(instance) {
+		t.Fatalf("group vertex does not have the expected edge to %v: got %v", instance, edges)
+	}
+}